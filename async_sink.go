@@ -0,0 +1,230 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package log
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DropPolicy controls what an AsyncSink does with a Msg when its internal
+// queue is full.
+type DropPolicy int
+
+const (
+	// DropOldest discards the oldest queued Msg to make room for the new one.
+	DropOldest DropPolicy = iota
+	// DropNewest discards the incoming Msg, leaving the queue unchanged.
+	DropNewest
+	// Block makes the caller wait until room is available in the queue.
+	Block
+)
+
+// A Flusher is implemented by Sinks that buffer output and want AsyncSink to
+// call Flush on a schedule rather than relying on the next Log call.
+type Flusher interface {
+	Flush() error
+}
+
+// An AsyncSink wraps another Sink and delivers Msgs to it from a background
+// goroutine, so that Logger.log doesn't wait on the wrapped Sink's I/O.
+// The exceptions are PANIC and FATAL messages, which Log delivers
+// synchronously, and the Block policy, which waits for room in a full queue.
+//
+// Sinks used behind an AsyncSink must not retain a *Msg past their Log call
+// returns unless they clone it; AsyncSink itself clones every Msg before
+// queueing it, since the original is returned to a pool once Logger.log's
+// call to AsyncSink.Log returns.
+type AsyncSink struct {
+	inner    Sink
+	innerMu  sync.Mutex
+	policy   DropPolicy
+	queue    chan *Msg
+	done     chan struct{}
+	wg       sync.WaitGroup
+	dropped  int64
+	enqueued int64
+	flushed  int64
+}
+
+// NewAsyncSink constructs an AsyncSink wrapping inner. capacity bounds the
+// number of queued messages. flushInterval, if positive, calls inner's
+// Flush method (if it implements Flusher) on that schedule. policy selects
+// what happens when the queue is full.
+func NewAsyncSink(inner Sink, capacity int, flushInterval time.Duration, policy DropPolicy) *AsyncSink {
+	s := &AsyncSink{
+		inner:  inner,
+		policy: policy,
+		queue:  make(chan *Msg, capacity),
+		done:   make(chan struct{}),
+	}
+	s.wg.Add(1)
+	go s.run(flushInterval)
+	return s
+}
+
+// run drains the queue into the inner Sink until Close is called.
+func (s *AsyncSink) run(flushInterval time.Duration) {
+	defer s.wg.Done()
+
+	var tick <-chan time.Time
+	if flushInterval > 0 {
+		ticker := time.NewTicker(flushInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case m := <-s.queue:
+			s.innerMu.Lock()
+			s.inner.Log(m)
+			s.innerMu.Unlock()
+		case <-tick:
+			if f, ok := s.inner.(Flusher); ok {
+				s.innerMu.Lock()
+				err := f.Flush()
+				s.innerMu.Unlock()
+				if err == nil {
+					atomic.AddInt64(&s.flushed, 1)
+				}
+			}
+		case <-s.done:
+			s.drain()
+			return
+		}
+	}
+}
+
+// drain delivers any messages still sitting in the queue.
+func (s *AsyncSink) drain() {
+	for {
+		select {
+		case m := <-s.queue:
+			s.innerMu.Lock()
+			s.inner.Log(m)
+			s.innerMu.Unlock()
+		default:
+			return
+		}
+	}
+}
+
+// Log enqueues a clone of m for delivery by the background goroutine,
+// applying the configured DropPolicy if the queue is full. PANIC and FATAL
+// messages bypass the queue and are delivered synchronously, so the process
+// can't exit (or a panic unwind) before they're written. innerMu keeps this
+// synchronous delivery mutually exclusive with run/drain's own calls to
+// inner.Log, since Sink implementations aren't required to be safe for
+// concurrent Log calls.
+func (s *AsyncSink) Log(m *Msg) {
+	if m.Level&(PANIC|FATAL) != 0 {
+		s.innerMu.Lock()
+		s.inner.Log(m)
+		s.innerMu.Unlock()
+		return
+	}
+
+	clone := m.clone()
+	switch s.policy {
+	case Block:
+		s.queue <- clone
+		atomic.AddInt64(&s.enqueued, 1)
+	case DropNewest:
+		select {
+		case s.queue <- clone:
+			atomic.AddInt64(&s.enqueued, 1)
+		default:
+			atomic.AddInt64(&s.dropped, 1)
+		}
+	default: // DropOldest
+		for {
+			select {
+			case s.queue <- clone:
+				atomic.AddInt64(&s.enqueued, 1)
+				return
+			default:
+				select {
+				case <-s.queue:
+					atomic.AddInt64(&s.dropped, 1)
+				default:
+				}
+			}
+		}
+	}
+}
+
+// MinVerbosity forwards to the wrapped Sink's MinVerbosityFilter, if it
+// implements one, so that Logger.log can filter V-leveled messages before
+// they're ever cloned and queued. If inner doesn't filter, AsyncSink accepts
+// every verbosity.
+func (s *AsyncSink) MinVerbosity() int {
+	if vf, ok := s.inner.(MinVerbosityFilter); ok {
+		return vf.MinVerbosity()
+	}
+	return math.MaxInt32
+}
+
+// Close stops the background goroutine and waits up to timeout for any
+// queued messages to be delivered to the wrapped Sink.
+func (s *AsyncSink) Close(timeout time.Duration) error {
+	close(s.done)
+
+	finished := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		return nil
+	case <-time.After(timeout):
+		return errors.New("log: AsyncSink Close timed out waiting for queued messages")
+	}
+}
+
+// Dropped returns the number of Msgs discarded so far due to a full queue.
+func (s *AsyncSink) Dropped() int64 {
+	return atomic.LoadInt64(&s.dropped)
+}
+
+// Enqueued returns the number of Msgs successfully queued so far, not
+// counting PANIC/FATAL messages delivered synchronously.
+func (s *AsyncSink) Enqueued() int64 {
+	return atomic.LoadInt64(&s.enqueued)
+}
+
+// Flushed returns the number of times a scheduled Flush of the wrapped Sink
+// succeeded.
+func (s *AsyncSink) Flushed() int64 {
+	return atomic.LoadInt64(&s.flushed)
+}
+
+// Queued returns the number of Msgs currently waiting in the queue.
+func (s *AsyncSink) Queued() int64 {
+	return int64(len(s.queue))
+}
@@ -0,0 +1,103 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package log
+
+import (
+	"io"
+	"os"
+)
+
+const (
+	ansiReset  = "\033[0m"
+	ansiCyan   = "\033[36m"
+	ansiYellow = "\033[33m"
+	ansiRed    = "\033[31m"
+)
+
+// A ConsoleSink writes formatted log messages to an io.Writer, adding ANSI
+// color per Level when the writer looks like a terminal that supports it.
+type ConsoleSink struct {
+	w         io.Writer
+	isTerm    bool
+	force     *bool
+	formatter Formatter
+}
+
+// NewConsoleSink constructs a ConsoleSink writing to w, auto-detecting
+// whether w is a color-capable terminal.
+func NewConsoleSink(w io.Writer) *ConsoleSink {
+	s := &ConsoleSink{w: w}
+	if f, ok := w.(*os.File); ok {
+		s.isTerm = isTerminal(f)
+	}
+	return s
+}
+
+// ForceColor overrides terminal auto-detection, forcing color on or off.
+func (s *ConsoleSink) ForceColor(force bool) {
+	s.force = &force
+}
+
+// SetFormatter installs the Formatter used to render each Msg, in place of
+// the default TextFormatter (with color following the sink's own TTY
+// detection). Custom field ordering is the main reason to replace it.
+func (s *ConsoleSink) SetFormatter(f Formatter) {
+	s.formatter = f
+}
+
+// colorEnabled reports whether s should emit ANSI color codes.
+func (s *ConsoleSink) colorEnabled() bool {
+	if s.force != nil {
+		return *s.force
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return s.isTerm
+}
+
+// colorFor returns the ANSI color escape for lvl, or "" if lvl has none.
+func colorFor(lvl Level) string {
+	switch lvl {
+	case DEBUG:
+		return ansiCyan
+	case WARNING:
+		return ansiYellow
+	case ERROR, PANIC, FATAL:
+		return ansiRed
+	default:
+		return ""
+	}
+}
+
+// Log writes a rendering of m to the underlying io.Writer.
+func (s *ConsoleSink) Log(m *Msg) {
+	f := s.formatter
+	if f == nil {
+		f = TextFormatter{Color: s.colorEnabled()}
+	}
+	s.w.Write(f.Format(m))
+}
@@ -0,0 +1,227 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package log
+
+import "fmt"
+
+// An Entry is a Logger bound to a fixed set of structured Fields.
+// Every message logged through an Entry carries those Fields along with it.
+type Entry struct {
+	logger *Logger
+	fields Fields
+}
+
+// WithField returns an Entry carrying the receiving Logger and a single field.
+func (l *Logger) WithField(key string, value interface{}) *Entry {
+	return &Entry{logger: l, fields: Fields{key: value}}
+}
+
+// WithFields returns an Entry carrying the receiving Logger and the given fields.
+func (l *Logger) WithFields(fields Fields) *Entry {
+	cp := make(Fields, len(fields))
+	for k, v := range fields {
+		cp[k] = v
+	}
+	return &Entry{logger: l, fields: cp}
+}
+
+// WithField returns a new Entry with key added to the receiving Entry's fields.
+func (e *Entry) WithField(key string, value interface{}) *Entry {
+	cp := make(Fields, len(e.fields)+1)
+	for k, v := range e.fields {
+		cp[k] = v
+	}
+	cp[key] = value
+	return &Entry{logger: e.logger, fields: cp}
+}
+
+// WithFields returns a new Entry with fields merged into the receiving Entry's fields.
+func (e *Entry) WithFields(fields Fields) *Entry {
+	cp := make(Fields, len(e.fields)+len(fields))
+	for k, v := range e.fields {
+		cp[k] = v
+	}
+	for k, v := range fields {
+		cp[k] = v
+	}
+	return &Entry{logger: e.logger, fields: cp}
+}
+
+// Debug will print in the manner of fmt.Print to the Entry's Logger if the DEBUG Level is enabled
+func (e *Entry) Debug(v ...interface{}) {
+	if e.logger.enabled&DEBUG != DEBUG {
+		return
+	}
+	e.logger.log(DEBUG, fmt.Sprint(v...), e.fields, 0)
+}
+
+// Debugln will print in the manner of fmt.Println to the Entry's Logger if the DEBUG Level is enabled
+func (e *Entry) Debugln(v ...interface{}) {
+	if e.logger.enabled&DEBUG != DEBUG {
+		return
+	}
+	e.logger.log(DEBUG, fmt.Sprintln(v...), e.fields, 0)
+}
+
+// Debugf will print in the manner of fmt.Printf to the Entry's Logger if the DEBUG Level is enabled
+func (e *Entry) Debugf(format string, v ...interface{}) {
+	if e.logger.enabled&DEBUG != DEBUG {
+		return
+	}
+	e.logger.log(DEBUG, fmt.Sprintf(format, v...), e.fields, 0)
+}
+
+// Print will print in the manner of fmt.Print to the Entry's Logger if the NORMAL Level is enabled
+func (e *Entry) Print(v ...interface{}) {
+	if e.logger.enabled&NORMAL != NORMAL {
+		return
+	}
+	e.logger.log(NORMAL, fmt.Sprint(v...), e.fields, 0)
+}
+
+// Println will print in the manner of fmt.Println to the Entry's Logger if the NORMAL Level is enabled
+func (e *Entry) Println(v ...interface{}) {
+	if e.logger.enabled&NORMAL != NORMAL {
+		return
+	}
+	e.logger.log(NORMAL, fmt.Sprintln(v...), e.fields, 0)
+}
+
+// Printf will print in the manner of fmt.Printf to the Entry's Logger if the NORMAL Level is enabled
+func (e *Entry) Printf(format string, v ...interface{}) {
+	if e.logger.enabled&NORMAL != NORMAL {
+		return
+	}
+	e.logger.log(NORMAL, fmt.Sprintf(format, v...), e.fields, 0)
+}
+
+// Warning will print in the manner of fmt.Print to the Entry's Logger if the WARNING Level is enabled
+func (e *Entry) Warning(v ...interface{}) {
+	if e.logger.enabled&WARNING != WARNING {
+		return
+	}
+	e.logger.log(WARNING, fmt.Sprint(v...), e.fields, 0)
+}
+
+// Warningln will print in the manner of fmt.Println to the Entry's Logger if the WARNING Level is enabled
+func (e *Entry) Warningln(v ...interface{}) {
+	if e.logger.enabled&WARNING != WARNING {
+		return
+	}
+	e.logger.log(WARNING, fmt.Sprintln(v...), e.fields, 0)
+}
+
+// Warningf will print in the manner of fmt.Printf to the Entry's Logger if the WARNING Level is enabled
+func (e *Entry) Warningf(format string, v ...interface{}) {
+	if e.logger.enabled&WARNING != WARNING {
+		return
+	}
+	e.logger.log(WARNING, fmt.Sprintf(format, v...), e.fields, 0)
+}
+
+// Error will print in the manner of fmt.Print to the Entry's Logger if the ERROR Level is enabled
+func (e *Entry) Error(v ...interface{}) {
+	if e.logger.enabled&ERROR != ERROR {
+		return
+	}
+	e.logger.log(ERROR, fmt.Sprint(v...), e.fields, 0)
+}
+
+// Errorln will print in the manner of fmt.Println to the Entry's Logger if the ERROR Level is enabled
+func (e *Entry) Errorln(v ...interface{}) {
+	if e.logger.enabled&ERROR != ERROR {
+		return
+	}
+	e.logger.log(ERROR, fmt.Sprintln(v...), e.fields, 0)
+}
+
+// Errorf will print in the manner of fmt.Printf to the Entry's Logger if the ERROR Level is enabled
+func (e *Entry) Errorf(format string, v ...interface{}) {
+	if e.logger.enabled&ERROR != ERROR {
+		return
+	}
+	e.logger.log(ERROR, fmt.Sprintf(format, v...), e.fields, 0)
+}
+
+// Panic will print in the manner of fmt.Print to the Entry's Logger if the PANIC Level is enabled
+// After logging the message, Panic will call panic()
+func (e *Entry) Panic(v ...interface{}) {
+	if e.logger.enabled&PANIC != PANIC {
+		return
+	}
+	msg := fmt.Sprint(v...)
+	e.logger.log(PANIC, msg, e.fields, 0)
+	panic(msg)
+}
+
+// Panicln will print in the manner of fmt.Println to the Entry's Logger if the PANIC Level is enabled
+// After logging the message, Panicln will call panic()
+func (e *Entry) Panicln(v ...interface{}) {
+	if e.logger.enabled&PANIC != PANIC {
+		return
+	}
+	msg := fmt.Sprintln(v...)
+	e.logger.log(PANIC, msg, e.fields, 0)
+	panic(msg)
+}
+
+// Panicf will print in the manner of fmt.Printf to the Entry's Logger if the PANIC Level is enabled
+// After logging the message, Panicf will call panic()
+func (e *Entry) Panicf(format string, v ...interface{}) {
+	if e.logger.enabled&PANIC != PANIC {
+		return
+	}
+	msg := fmt.Sprintf(format, v...)
+	e.logger.log(PANIC, msg, e.fields, 0)
+	panic(msg)
+}
+
+// Fatal will print in the manner of fmt.Print to the Entry's Logger if the FATAL Level is enabled
+// After logging the message, Fatal will call the Logger's exit func
+func (e *Entry) Fatal(v ...interface{}) {
+	if e.logger.enabled&FATAL != FATAL {
+		return
+	}
+	e.logger.log(FATAL, fmt.Sprint(v...), e.fields, 0)
+	e.logger.exit()
+}
+
+// Fatalln will print in the manner of fmt.Println to the Entry's Logger if the FATAL Level is enabled
+// After logging the message, Fatalln will call the Logger's exit func
+func (e *Entry) Fatalln(v ...interface{}) {
+	if e.logger.enabled&FATAL != FATAL {
+		return
+	}
+	e.logger.log(FATAL, fmt.Sprintln(v...), e.fields, 0)
+	e.logger.exit()
+}
+
+// Fatalf will print in the manner of fmt.Printf to the Entry's Logger if the FATAL Level is enabled
+// After logging the message, Fatalf will call the Logger's exit func
+func (e *Entry) Fatalf(format string, v ...interface{}) {
+	if e.logger.enabled&FATAL != FATAL {
+		return
+	}
+	e.logger.log(FATAL, fmt.Sprintf(format, v...), e.fields, 0)
+	e.logger.exit()
+}
@@ -20,9 +20,10 @@
 // FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
 // DEALINGS IN THE SOFTWARE.
 
-package logger
+package log
 
 import (
+	"bufio"
 	"bytes"
 	"errors"
 	"io"
@@ -124,9 +125,10 @@ func (file *FileSink) Log(m *Msg) {
 	}
 }
 
-// roll rolls the file content
+// roll rolls the file content, keeping the tail of the file starting at the
+// nearest line boundary at or after file.keep bytes from the end, so a
+// multi-byte UTF-8 sequence or a log line is never split across the cut.
 func (file *FileSink) roll() {
-	keep := file.keep
 	out := file.out
 
 	// make sure everything is really on disk
@@ -141,8 +143,14 @@ func (file *FileSink) roll() {
 	}
 	defer in.Close()
 
-	// seek the reader back from the end of the file last position
-	if _, err = in.Seek(0-keep, io.SeekEnd); err != nil {
+	// find a line boundary at or after keep bytes back from the end
+	start, err := findLineStart(in, file.keep)
+	if err != nil {
+		panic(err)
+	}
+
+	// seek the reader to that boundary
+	if _, err = in.Seek(start, io.SeekStart); err != nil {
 		panic(err)
 	}
 
@@ -152,7 +160,8 @@ func (file *FileSink) roll() {
 	}
 
 	// copy bytes back to the beginning of the file
-	if keep, err = io.CopyN(out, in, keep); err != nil {
+	n, err := io.Copy(out, in)
+	if err != nil {
 		panic(err)
 	}
 
@@ -162,9 +171,33 @@ func (file *FileSink) roll() {
 	}
 
 	// resize the file
-	if err = out.Truncate(keep); err != nil {
+	if err = out.Truncate(n); err != nil {
 		panic(err)
 	}
 
-	file.size = keep
+	file.size = n
+}
+
+// findLineStart returns the offset of the first byte after the nearest '\n'
+// at or after keep bytes back from the end of in, so that a seek to the
+// returned offset always lands on a line boundary. It returns 0 if keep
+// covers the whole file.
+func findLineStart(in *os.File, keep int64) (int64, error) {
+	size, err := in.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+	pos := size - keep
+	if pos <= 0 {
+		return 0, nil
+	}
+
+	if _, err = in.Seek(pos, io.SeekStart); err != nil {
+		return 0, err
+	}
+	line, err := bufio.NewReader(in).ReadBytes('\n')
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+	return pos + int64(len(line)), nil
 }
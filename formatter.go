@@ -0,0 +1,172 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// A Formatter renders a Msg as the bytes a Sink should write for it.
+// Sinks that want pluggable rendering accept one via SetFormatter.
+type Formatter interface {
+	Format(m *Msg) []byte
+}
+
+// A TextFormatter renders a Msg in the package's original human-readable
+// layout: "Date Time Level File:Line Msg". With Color set, it wraps the
+// line in the same ANSI colors ConsoleSink uses per Level.
+type TextFormatter struct {
+	Color bool
+}
+
+// Format renders m in the text layout.
+func (f TextFormatter) Format(m *Msg) []byte {
+	var buf bytes.Buffer
+	color := ""
+	if f.Color {
+		color = colorFor(m.Level)
+	}
+	if color != "" {
+		buf.WriteString(color)
+	}
+	m.PrintDate(&buf)
+	buf.WriteString(" ")
+	m.PrintTime(&buf)
+	buf.WriteString(" ")
+	m.PrintLevel(&buf)
+	buf.WriteString(" ")
+	m.PrintFileLine(&buf)
+	buf.WriteString(" ")
+	m.PrintMsg(&buf)
+	if color != "" {
+		buf.WriteString(ansiReset)
+	}
+	return buf.Bytes()
+}
+
+// A JSONFormatter renders a Msg as one JSON object, merging Fields in
+// alongside level, time, file, line, and msg.
+type JSONFormatter struct{}
+
+// Format renders m as a single line of JSON.
+func (f JSONFormatter) Format(m *Msg) []byte {
+	obj := make(map[string]interface{}, len(m.Fields)+5)
+	for k, v := range m.Fields {
+		obj[k] = v
+	}
+	obj["level"] = strings.Trim(m.Level.String(), "[]")
+	obj["time"] = m.Time.Format(time.RFC3339)
+	obj["file"] = m.File
+	obj["line"] = m.Line
+	obj["msg"] = strings.TrimSuffix(m.Body, "\n")
+	if m.VLevel > 0 {
+		obj["vlevel"] = m.VLevel
+	}
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil
+	}
+	return append(b, '\n')
+}
+
+// A LogfmtFormatter renders a Msg as a single line of space-separated
+// key=value pairs, following the go-logfmt encoding rules: bare tokens for
+// safe strings, double-quoted with \"/\\/\n/\t escapes otherwise, and a
+// bare "key=" for empty values.
+type LogfmtFormatter struct{}
+
+// Format renders m in logfmt.
+func (f LogfmtFormatter) Format(m *Msg) []byte {
+	var buf bytes.Buffer
+	writeLogfmtPair(&buf, "time", m.Time.Format(time.RFC3339))
+	buf.WriteByte(' ')
+	writeLogfmtPair(&buf, "level", strings.Trim(m.Level.String(), "[]"))
+	buf.WriteByte(' ')
+	writeLogfmtPair(&buf, "file", m.File)
+	buf.WriteByte(' ')
+	writeLogfmtPair(&buf, "line", strconv.Itoa(m.Line))
+	if m.VLevel > 0 {
+		buf.WriteByte(' ')
+		writeLogfmtPair(&buf, "vlevel", strconv.Itoa(m.VLevel))
+	}
+	buf.WriteByte(' ')
+	writeLogfmtPair(&buf, "msg", strings.TrimSuffix(m.Body, "\n"))
+
+	keys := make([]string, 0, len(m.Fields))
+	for k := range m.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		buf.WriteByte(' ')
+		writeLogfmtPair(&buf, k, formatLogfmtValue(m.Fields[k]))
+	}
+
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
+
+// formatLogfmtValue stringifies a field value for logfmt encoding.
+func formatLogfmtValue(v interface{}) string {
+	switch s := v.(type) {
+	case string:
+		return s
+	case error:
+		return s.Error()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// writeLogfmtPair writes "key=value" to buf, quoting value when it isn't a
+// safe bare token.
+func writeLogfmtPair(buf *bytes.Buffer, key, value string) {
+	buf.WriteString(key)
+	buf.WriteByte('=')
+	if value == "" {
+		return
+	}
+	if logfmtSafe(value) {
+		buf.WriteString(value)
+		return
+	}
+	buf.WriteString(strconv.Quote(value))
+}
+
+// logfmtSafe reports whether value can be written as a bare logfmt token.
+func logfmtSafe(value string) bool {
+	for _, r := range value {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError {
+			return false
+		}
+	}
+	return true
+}
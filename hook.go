@@ -0,0 +1,54 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package log
+
+// A Hook is a side-effect triggered by log messages at selected Levels, such
+// as notifying Sentry, syslog, or paging on-call on a FATAL. Unlike a Sink,
+// a Hook isn't responsible for primary log output; a Hook that errors does
+// not stop other Hooks or Sinks from running.
+type Hook interface {
+	// Levels returns the bit mask of Levels this Hook wants to fire on.
+	Levels() Level
+	// Fire is called with every Msg at a Level in Levels. A returned error
+	// is ignored by the Logger.
+	Fire(m *Msg) error
+}
+
+// AddHook registers hook to run after the attached Sinks on every future
+// call at a Level in hook.Levels().
+func (l *Logger) AddHook(hook Hook) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = append(l.hooks, hook)
+}
+
+// fireHooks calls Fire on every registered Hook whose Levels match m.Level.
+// Errors are swallowed; one Hook's failure must not affect the others.
+func (l *Logger) fireHooks(m *Msg) {
+	for _, hook := range l.hooks {
+		if hook.Levels()&m.Level == 0 {
+			continue
+		}
+		hook.Fire(m)
+	}
+}
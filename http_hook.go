@@ -0,0 +1,101 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// An HTTPHook POSTs the body of matching log messages as JSON to a webhook,
+// e.g. an incident tool or a custom alerting endpoint.
+type HTTPHook struct {
+	levels     Level
+	url        string
+	client     *http.Client
+	maxRetries int
+	backoff    time.Duration
+}
+
+// NewHTTPHook returns an HTTPHook that POSTs to url on every message at a
+// Level in levels, retrying up to maxRetries times with exponential backoff
+// starting at initialBackoff.
+func NewHTTPHook(url string, levels Level, maxRetries int, initialBackoff time.Duration) *HTTPHook {
+	return &HTTPHook{
+		levels:     levels,
+		url:        url,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		maxRetries: maxRetries,
+		backoff:    initialBackoff,
+	}
+}
+
+// Levels returns the bit mask of Levels this Hook fires on.
+func (h *HTTPHook) Levels() Level {
+	return h.levels
+}
+
+// Fire marshals m as a JSON object {level, time, file, line, msg} and
+// dispatches the POST to h.url on a background goroutine, so that a slow or
+// unreachable webhook's retry/backoff loop never runs while fireHooks holds
+// the Logger's lock. Since a Hook's errors are already swallowed by
+// fireHooks, Fire itself always returns nil once the message is marshaled;
+// delivery failures are simply dropped after the retries are exhausted.
+func (h *HTTPHook) Fire(m *Msg) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"level": m.Level.String(),
+		"time":  m.Time.Format(time.RFC3339),
+		"file":  m.File,
+		"line":  m.Line,
+		"msg":   m.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	go h.deliver(payload)
+	return nil
+}
+
+// deliver POSTs payload to h.url, retrying with exponential backoff up to
+// h.maxRetries times.
+func (h *HTTPHook) deliver(payload []byte) {
+	backoff := h.backoff
+	for attempt := 0; attempt <= h.maxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		resp, err := h.client.Post(h.url, "application/json", bytes.NewReader(payload))
+		if err != nil {
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return
+		}
+	}
+}
@@ -29,16 +29,23 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // A Logger is a frontend to a logging system that writes log messages to one or more Sinks.
 // A Logger is safe to use concurrently and serializes calls to attached Sinks
 type Logger struct {
-	mu      sync.Mutex
-	enabled Level
-	exit    func()
-	sinks   []Sink
+	mu        sync.Mutex
+	enabled   Level
+	exit      func()
+	sinks     []Sink
+	hooks     []Hook
+	verbosity int32
+	vmodule   atomic.Value
+	// vmoduleCache caches the vmodule rule level resolved for each V() call
+	// site (keyed by its PC), so repeat calls skip re-matching the rules.
+	vmoduleCache sync.Map
 }
 
 // NewLogger creates a new Logger which will write log messages to the Sinks passed at the Levels enabled.
@@ -52,12 +59,15 @@ func NewLogger(enabled Level, exit func(), sinks ...Sink) *Logger {
 }
 
 // log creates a Msg and writes it to the attached Sinks
-func (l *Logger) log(lvl Level, body string) {
-	var msg = &Msg{
-		Level: lvl,
-		Time:  time.Now(),
-		Body:  body,
-	}
+func (l *Logger) log(lvl Level, body string, fields Fields, vlevel int) {
+	msg := getMsg()
+	defer putMsg(msg)
+
+	msg.Level = lvl
+	msg.Time = time.Now()
+	msg.Body = body
+	msg.Fields = fields
+	msg.VLevel = vlevel
 
 	var ok bool
 	_, msg.File, msg.Line, ok = runtime.Caller(2)
@@ -78,8 +88,12 @@ func (l *Logger) log(lvl Level, body string) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	for _, sink := range l.sinks {
+		if vf, ok := sink.(MinVerbosityFilter); ok && msg.VLevel > 0 && msg.VLevel > vf.MinVerbosity() {
+			continue
+		}
 		sink.Log(msg)
 	}
+	l.fireHooks(msg)
 }
 
 // DebugFunc will execute fn if the DEBUG Level is enabled on the standard Logger
@@ -88,7 +102,7 @@ func (l *Logger) DebugFunc(fn func() string) {
 	if l.enabled&DEBUG != DEBUG {
 		return
 	}
-	l.log(DEBUG, fn())
+	l.log(DEBUG, fn(), nil, 0)
 }
 
 // Debug will print in the manner of fmt.Print to the standard Logger if the DEBUG Level is enabled
@@ -96,7 +110,7 @@ func (l *Logger) Debug(v ...interface{}) {
 	if l.enabled&DEBUG != DEBUG {
 		return
 	}
-	l.log(DEBUG, fmt.Sprint(v...))
+	l.log(DEBUG, fmt.Sprint(v...), nil, 0)
 }
 
 // Debugln will print in the manner of fmt.Println to the standard Logger if the DEBUG Level is enabled
@@ -104,7 +118,7 @@ func (l *Logger) Debugln(v ...interface{}) {
 	if l.enabled&DEBUG != DEBUG {
 		return
 	}
-	l.log(DEBUG, fmt.Sprintln(v...))
+	l.log(DEBUG, fmt.Sprintln(v...), nil, 0)
 }
 
 // Debugf will print in the manner of fmt.Printf to the standard Logger if the DEBUG Level is enabled
@@ -112,7 +126,7 @@ func (l *Logger) Debugf(format string, v ...interface{}) {
 	if l.enabled&DEBUG != DEBUG {
 		return
 	}
-	l.log(DEBUG, fmt.Sprintf(format, v...))
+	l.log(DEBUG, fmt.Sprintf(format, v...), nil, 0)
 }
 
 // Print will print in the manner of fmt.Print to the standard Logger if the NORMAL Level is enabled
@@ -120,7 +134,7 @@ func (l *Logger) Print(v ...interface{}) {
 	if l.enabled&NORMAL != NORMAL {
 		return
 	}
-	l.log(NORMAL, fmt.Sprint(v...))
+	l.log(NORMAL, fmt.Sprint(v...), nil, 0)
 }
 
 // Println will print in the manner of fmt.Println to the standard Logger if the NORMAL Level is enabled
@@ -128,7 +142,7 @@ func (l *Logger) Println(v ...interface{}) {
 	if l.enabled&NORMAL != NORMAL {
 		return
 	}
-	l.log(NORMAL, fmt.Sprintln(v...))
+	l.log(NORMAL, fmt.Sprintln(v...), nil, 0)
 }
 
 // Printf will print in the manner of fmt.Printf to the standard Logger if the NORMAL Level is enabled
@@ -136,7 +150,7 @@ func (l *Logger) Printf(format string, v ...interface{}) {
 	if l.enabled&NORMAL != NORMAL {
 		return
 	}
-	l.log(NORMAL, fmt.Sprintf(format, v...))
+	l.log(NORMAL, fmt.Sprintf(format, v...), nil, 0)
 }
 
 // Warning will print in the manner of fmt.Print to the standard Logger if the WARNING Level is enabled
@@ -144,7 +158,7 @@ func (l *Logger) Warning(v ...interface{}) {
 	if l.enabled&WARNING != WARNING {
 		return
 	}
-	l.log(WARNING, fmt.Sprint(v...))
+	l.log(WARNING, fmt.Sprint(v...), nil, 0)
 }
 
 // Warningln will print in the manner of fmt.Println to the standard Logger if the WARNING Level is enabled
@@ -152,7 +166,7 @@ func (l *Logger) Warningln(v ...interface{}) {
 	if l.enabled&WARNING != WARNING {
 		return
 	}
-	l.log(WARNING, fmt.Sprintln(v...))
+	l.log(WARNING, fmt.Sprintln(v...), nil, 0)
 }
 
 // Warningf will print in the manner of fmt.Printf to the standard Logger if the WARNING Level is enabled
@@ -160,7 +174,7 @@ func (l *Logger) Warningf(format string, v ...interface{}) {
 	if l.enabled&WARNING != WARNING {
 		return
 	}
-	l.log(WARNING, fmt.Sprintf(format, v...))
+	l.log(WARNING, fmt.Sprintf(format, v...), nil, 0)
 }
 
 // Error will print in the manner of fmt.Print to the standard Logger if the ERROR Level is enabled
@@ -168,7 +182,7 @@ func (l *Logger) Error(v ...interface{}) {
 	if l.enabled&ERROR != ERROR {
 		return
 	}
-	l.log(ERROR, fmt.Sprint(v...))
+	l.log(ERROR, fmt.Sprint(v...), nil, 0)
 }
 
 // Errorln will print in the manner of fmt.Println to the standard Logger if the ERROR Level is enabled
@@ -176,7 +190,7 @@ func (l *Logger) Errorln(v ...interface{}) {
 	if l.enabled&ERROR != ERROR {
 		return
 	}
-	l.log(ERROR, fmt.Sprintln(v...))
+	l.log(ERROR, fmt.Sprintln(v...), nil, 0)
 }
 
 // Errorf will print in the manner of fmt.Printf to the standard Logger if the ERROR Level is enabled
@@ -184,7 +198,7 @@ func (l *Logger) Errorf(format string, v ...interface{}) {
 	if l.enabled&ERROR != ERROR {
 		return
 	}
-	l.log(ERROR, fmt.Sprintf(format, v...))
+	l.log(ERROR, fmt.Sprintf(format, v...), nil, 0)
 }
 
 // Panic will print in the manner of fmt.Print to the standard Logger if the PANIC Level is enabled
@@ -194,7 +208,7 @@ func (l *Logger) Panic(v ...interface{}) {
 		return
 	}
 	msg := fmt.Sprint(v...)
-	l.log(PANIC, msg)
+	l.log(PANIC, msg, nil, 0)
 	panic(msg)
 }
 
@@ -205,7 +219,7 @@ func (l *Logger) Panicln(v ...interface{}) {
 		return
 	}
 	msg := fmt.Sprintln(v...)
-	l.log(PANIC, msg)
+	l.log(PANIC, msg, nil, 0)
 	panic(msg)
 }
 
@@ -216,7 +230,7 @@ func (l *Logger) Panicf(format string, v ...interface{}) {
 		return
 	}
 	msg := fmt.Sprintf(format, v...)
-	l.log(PANIC, msg)
+	l.log(PANIC, msg, nil, 0)
 	panic(msg)
 }
 
@@ -226,7 +240,7 @@ func (l *Logger) Fatal(v ...interface{}) {
 	if l.enabled&FATAL != FATAL {
 		return
 	}
-	l.log(FATAL, fmt.Sprint(v...))
+	l.log(FATAL, fmt.Sprint(v...), nil, 0)
 	l.exit()
 }
 
@@ -236,7 +250,7 @@ func (l *Logger) Fatalln(v ...interface{}) {
 	if l.enabled&FATAL != FATAL {
 		return
 	}
-	l.log(FATAL, fmt.Sprintln(v...))
+	l.log(FATAL, fmt.Sprintln(v...), nil, 0)
 	l.exit()
 }
 
@@ -246,6 +260,6 @@ func (l *Logger) Fatalf(format string, v ...interface{}) {
 	if l.enabled&FATAL != FATAL {
 		return
 	}
-	l.log(FATAL, fmt.Sprintf(format, v...))
+	l.log(FATAL, fmt.Sprintf(format, v...), nil, 0)
 	l.exit()
 }
@@ -20,11 +20,12 @@
 // FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
 // DEALINGS IN THE SOFTWARE.
 
-package logger
+package log
 
 import (
 	"fmt"
 	"io"
+	"sync"
 	"time"
 )
 
@@ -70,13 +71,20 @@ func (lvl Level) String() string {
 	}
 }
 
+// Fields is a set of structured key/value pairs attached to a Msg.
+type Fields map[string]interface{}
+
 // Msg is a log message
 type Msg struct {
-	Level Level
-	Time  time.Time
-	File  string
-	Line  int
-	Body  string
+	Level  Level
+	Time   time.Time
+	File   string
+	Line   int
+	Body   string
+	Fields Fields
+	// VLevel is the numeric verbosity the Msg was logged at via V(n), or 0
+	// for messages logged through the ordinary Level methods.
+	VLevel int
 }
 
 // PrintDate prints the date of the receiving Msg to w
@@ -119,3 +127,35 @@ func (m *Msg) PrintMsg(w io.Writer) (n int, err error) {
 	}
 	return fmt.Fprint(w, m.Body)
 }
+
+// clone returns a copy of m that is safe to retain after the Sink.Log call
+// that received m has returned, for example because it was handed off to
+// another goroutine by an AsyncSink.
+func (m *Msg) clone() *Msg {
+	cp := *m
+	if m.Fields != nil {
+		cp.Fields = make(Fields, len(m.Fields))
+		for k, v := range m.Fields {
+			cp.Fields[k] = v
+		}
+	}
+	return &cp
+}
+
+// msgPool recycles Msg values so that logging doesn't allocate on every call.
+var msgPool = sync.Pool{
+	New: func() interface{} {
+		return new(Msg)
+	},
+}
+
+// getMsg fetches a zeroed Msg from msgPool.
+func getMsg() *Msg {
+	return msgPool.Get().(*Msg)
+}
+
+// putMsg returns m to msgPool for reuse. Callers must not use m afterward.
+func putMsg(m *Msg) {
+	*m = Msg{}
+	msgPool.Put(m)
+}
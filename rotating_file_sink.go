@@ -0,0 +1,367 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package log
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// A RotatingFileSink writes log messages to a file, archiving rather than
+// truncating its content: once MaxSize or MaxAge is reached, the current
+// file is closed, renamed with a timestamp suffix, and a fresh file is
+// opened at the original path. Unlike FileSink, no history is ever lost to
+// an in-place roll; instead old archives are pruned by MaxBackups and
+// MaxAgeDays.
+//
+// RotatingFileSink is a separate Sink implementation; FileSink is unchanged
+// and remains the right choice for a single bounded file.
+type RotatingFileSink struct {
+	// Path is the active log file. Archives are written alongside it as
+	// "<Path>.<timestamp>.log" (with ".gz" appended when Compress is set).
+	Path string
+	// MaxSize rotates the file once it exceeds this many bytes. Zero disables.
+	MaxSize int64
+	// MaxAge rotates the file once it has been open this long. Zero disables.
+	MaxAge time.Duration
+	// MaxBackups retains at most this many archives, deleting the oldest
+	// first. Zero means unlimited.
+	MaxBackups int
+	// MaxAgeDays deletes archives older than this many days. Zero disables.
+	MaxAgeDays int
+	// Compress gzips archives on a background goroutine after rotation.
+	Compress bool
+	// SymlinkName, if set, is kept pointing at the currently-active file so
+	// external tailers (e.g. `tail -F`) keep following across rotations.
+	// The link is (re)created each time the active file is opened.
+	// NewRotatingFileSink opens the first file without it, so when the field
+	// is set after construction the link first appears at the next rotation.
+	SymlinkName string
+	// FsyncOnFatal calls File.Sync after writing a PANIC or FATAL message,
+	// so it's guaranteed to be on disk before the process exits.
+	FsyncOnFatal bool
+
+	mu     sync.Mutex
+	out    *os.File
+	size   int64
+	opened time.Time
+	buf    bytes.Buffer
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewRotatingFileSink constructs a RotatingFileSink writing to path, opening
+// (or creating) the file immediately. If maxAge is positive, a background
+// timer also rotates the file once it's been open that long, so MaxAge is
+// enforced even if the sink goes idle and Log is never called again to
+// notice on its own.
+func NewRotatingFileSink(path string, maxSize int64, maxAge time.Duration, maxBackups, maxAgeDays int, compress bool) (*RotatingFileSink, error) {
+	s := &RotatingFileSink{
+		Path:       path,
+		MaxSize:    maxSize,
+		MaxAge:     maxAge,
+		MaxBackups: maxBackups,
+		MaxAgeDays: maxAgeDays,
+		Compress:   compress,
+	}
+	if err := s.openCurrent(); err != nil {
+		return nil, err
+	}
+	if maxAge > 0 {
+		s.done = make(chan struct{})
+		s.wg.Add(1)
+		go s.watchAge()
+	}
+	return s, nil
+}
+
+// ageCheckInterval bounds how often watchAge wakes to check the active
+// file's age. It's derived from MaxAge so short MaxAge values still rotate
+// promptly, with a floor so the timer doesn't busy-poll.
+func ageCheckInterval(maxAge time.Duration) time.Duration {
+	interval := maxAge / 4
+	if interval < time.Second {
+		interval = time.Second
+	}
+	return interval
+}
+
+// watchAge rotates the active file on a timer once it's older than MaxAge,
+// independent of Log being called. It exits once Close closes s.done.
+func (s *RotatingFileSink) watchAge() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(ageCheckInterval(s.MaxAge))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.mu.Lock()
+			if s.out != nil && time.Since(s.opened) >= s.MaxAge {
+				s.rotate()
+			}
+			s.mu.Unlock()
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// openCurrent opens (or creates) the active log file and records its size.
+func (s *RotatingFileSink) openCurrent() error {
+	out, err := os.OpenFile(s.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, os.FileMode(0666))
+	if err != nil {
+		return err
+	}
+	info, err := out.Stat()
+	if err != nil {
+		out.Close()
+		return err
+	}
+	s.out = out
+	s.size = info.Size()
+	s.opened = time.Now()
+
+	if s.SymlinkName != "" {
+		os.Remove(s.SymlinkName)
+		if err := os.Symlink(symlinkTarget(s.Path, s.SymlinkName), s.SymlinkName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// symlinkTarget returns the value to pass to os.Symlink so that symlinkName
+// resolves to path, regardless of which directory symlinkName itself lives
+// in. A symlink target is resolved relative to the symlink's own directory,
+// not the caller's, so a bare filepath.Base(path) only works when the two
+// live side by side; this computes the target relative to symlinkName's
+// directory instead, falling back to path unchanged if that can't be done.
+func symlinkTarget(path, symlinkName string) string {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	absSymDir, err := filepath.Abs(filepath.Dir(symlinkName))
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(absSymDir, absPath)
+	if err != nil {
+		return absPath
+	}
+	return rel
+}
+
+// Log writes a log message to the active file, rotating first if needed.
+func (s *RotatingFileSink) Log(m *Msg) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.out == nil {
+		return
+	}
+
+	if s.shouldRotate() {
+		if err := s.rotate(); err != nil {
+			return
+		}
+	}
+
+	buf := &s.buf
+	m.PrintDate(buf)
+	buf.WriteString(" ")
+	m.PrintTime(buf)
+	buf.WriteString(" ")
+	m.PrintLevel(buf)
+	buf.WriteString(" ")
+	m.PrintFileLine(buf)
+	buf.WriteString(" ")
+	m.PrintMsg(buf)
+	n, _ := s.out.Write(buf.Bytes())
+	buf.Reset()
+
+	s.size += int64(n)
+
+	if s.FsyncOnFatal && (m.Level&(PANIC|FATAL) != 0) {
+		s.out.Sync()
+	}
+}
+
+// shouldRotate reports whether the active file has crossed MaxSize or MaxAge.
+func (s *RotatingFileSink) shouldRotate() bool {
+	if s.MaxSize > 0 && s.size >= s.MaxSize {
+		return true
+	}
+	if s.MaxAge > 0 && time.Since(s.opened) >= s.MaxAge {
+		return true
+	}
+	return false
+}
+
+// Rotate closes the active file, archives it, and opens a fresh one. It is
+// exported so callers can trigger rotation on an external signal.
+func (s *RotatingFileSink) Rotate() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rotate()
+}
+
+func (s *RotatingFileSink) rotate() error {
+	if err := s.out.Close(); err != nil {
+		return err
+	}
+
+	archive := s.archiveName(time.Now())
+	if err := os.Rename(s.Path, archive); err != nil {
+		return err
+	}
+
+	if s.Compress {
+		go compressArchive(archive)
+	}
+
+	go s.prune()
+
+	return s.openCurrent()
+}
+
+// archiveName returns the path to archive the active file to at t. Archive
+// timestamps only have second granularity, so two rotations in the same
+// second would otherwise collide and os.Rename would silently overwrite the
+// earlier archive; archiveName appends "-N" before the .log/.gz suffix,
+// trying successive N until it finds a name nothing already occupies.
+func (s *RotatingFileSink) archiveName(t time.Time) string {
+	stamp := t.Format("2006-01-02T15-04-05")
+	archive := fmt.Sprintf("%s.%s.log", s.Path, stamp)
+	for n := 1; fileExists(archive) || fileExists(archive+".gz"); n++ {
+		archive = fmt.Sprintf("%s.%s-%d.log", s.Path, stamp, n)
+	}
+	return archive
+}
+
+// fileExists reports whether path names an existing file or directory,
+// following symlinks.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// prune deletes archives beyond MaxBackups and older than MaxAgeDays.
+func (s *RotatingFileSink) prune() {
+	dir := filepath.Dir(s.Path)
+	base := filepath.Base(s.Path)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	var archives []os.DirEntry
+	for _, e := range entries {
+		name := e.Name()
+		if name == base {
+			continue
+		}
+		if len(name) > len(base) && name[:len(base)+1] == base+"." {
+			archives = append(archives, e)
+		}
+	}
+
+	sort.Slice(archives, func(i, j int) bool {
+		return archives[i].Name() < archives[j].Name()
+	})
+
+	if s.MaxAgeDays > 0 {
+		cutoff := time.Now().AddDate(0, 0, -s.MaxAgeDays)
+		kept := archives[:0]
+		for _, e := range archives {
+			info, err := e.Info()
+			if err == nil && info.ModTime().Before(cutoff) {
+				os.Remove(filepath.Join(dir, e.Name()))
+				continue
+			}
+			kept = append(kept, e)
+		}
+		archives = kept
+	}
+
+	if s.MaxBackups > 0 && len(archives) > s.MaxBackups {
+		for _, e := range archives[:len(archives)-s.MaxBackups] {
+			os.Remove(filepath.Join(dir, e.Name()))
+		}
+	}
+}
+
+// compressArchive gzips path in place, replacing it with a ".gz" file.
+func compressArchive(path string) {
+	in, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer in.Close()
+
+	out, err := os.Create(path + ".gz")
+	if err != nil {
+		return
+	}
+
+	gw := gzip.NewWriter(out)
+	if _, err := io.Copy(gw, in); err != nil {
+		gw.Close()
+		out.Close()
+		os.Remove(path + ".gz")
+		return
+	}
+	gw.Close()
+	out.Close()
+
+	os.Remove(path)
+}
+
+// Close stops the age-rotation timer, if running, and closes the active
+// file, rendering the RotatingFileSink unusable.
+func (s *RotatingFileSink) Close() error {
+	if s.done != nil {
+		close(s.done)
+		s.wg.Wait()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := s.out
+	s.out = nil
+	if out == nil {
+		return nil
+	}
+	return out.Close()
+}
@@ -23,7 +23,6 @@
 package log
 
 import (
-	"bytes"
 	"os"
 )
 
@@ -38,7 +37,13 @@ type Sink interface {
 // It will ignore log messages at levels WARNING, ERROR, PANIC, and FATAL.
 // It will write log messages at levels DEBUG and NORMAL.
 type StdOutSink struct {
-	buf bytes.Buffer
+	formatter Formatter
+}
+
+// SetFormatter installs the Formatter used to render each Msg.
+// Without a call to SetFormatter, a StdOutSink renders with TextFormatter.
+func (s *StdOutSink) SetFormatter(f Formatter) {
+	s.formatter = f
 }
 
 // Log writes a log message to STDOUT
@@ -47,25 +52,24 @@ func (s *StdOutSink) Log(m *Msg) {
 	if m.Level&(WARNING|ERROR|PANIC|FATAL) > 0 {
 		return
 	}
-	buf := &s.buf
-	m.PrintDate(buf)
-	buf.WriteString(" ")
-	m.PrintTime(buf)
-	buf.WriteString(" ")
-	m.PrintLevel(buf)
-	buf.WriteString(" ")
-	m.PrintFileLine(buf)
-	buf.WriteString(" ")
-	m.PrintMsg(buf)
-	buf.WriteTo(os.Stdout)
-	buf.Reset()
+	f := s.formatter
+	if f == nil {
+		f = TextFormatter{}
+	}
+	os.Stdout.Write(f.Format(m))
 }
 
 // A StdErrSink is a Sink which writes to STDERR.
 // It will ignore log messages at levels DEBUG and NORMAL.
 // It will write log messages at levels WARNING, ERROR, PANIC, and FATAL.
 type StdErrSink struct {
-	buf bytes.Buffer
+	formatter Formatter
+}
+
+// SetFormatter installs the Formatter used to render each Msg.
+// Without a call to SetFormatter, a StdErrSink renders with TextFormatter.
+func (s *StdErrSink) SetFormatter(f Formatter) {
+	s.formatter = f
 }
 
 // Log writes a log message to STDERR
@@ -74,16 +78,9 @@ func (s *StdErrSink) Log(m *Msg) {
 	if m.Level&(DEBUG|NORMAL) > 0 {
 		return
 	}
-	buf := &s.buf
-	m.PrintDate(buf)
-	buf.WriteString(" ")
-	m.PrintTime(buf)
-	buf.WriteString(" ")
-	m.PrintLevel(buf)
-	buf.WriteString(" ")
-	m.PrintFileLine(buf)
-	buf.WriteString(" ")
-	m.PrintMsg(buf)
-	buf.WriteTo(os.Stderr)
-	buf.Reset()
+	f := s.formatter
+	if f == nil {
+		f = TextFormatter{}
+	}
+	os.Stderr.Write(f.Format(m))
 }
\ No newline at end of file
@@ -25,6 +25,7 @@ package log
 import (
 	"fmt"
 	"os"
+	"strconv"
 )
 
 var std = &Logger{
@@ -60,13 +61,60 @@ func SetSinks(sinks ...Sink) {
 	std.sinks = sinks
 }
 
+// AddHook registers hook to run after the standard Logger's Sinks.
+func AddHook(hook Hook) {
+	std.AddHook(hook)
+}
+
+// WithField returns an Entry bound to the standard Logger carrying a single field.
+func WithField(key string, value interface{}) *Entry {
+	return std.WithField(key, value)
+}
+
+// WithFields returns an Entry bound to the standard Logger carrying the given fields.
+func WithFields(fields Fields) *Entry {
+	return std.WithFields(fields)
+}
+
+// V reports whether verbosity level is enabled on the standard Logger.
+//
+// This calls std.vAtDepth directly rather than std.V: both V and this
+// wrapper sit exactly one frame above vAtDepth, so passing the same skip
+// depth resolves VModule rules against the caller of this function instead
+// of against std.go itself.
+func V(level int) Verbose {
+	return std.vAtDepth(level, 2)
+}
+
+// SetVerbosity sets the numeric verbosity level on the standard Logger.
+func SetVerbosity(n int) {
+	std.SetVerbosity(n)
+}
+
+// VModule sets per-file verbosity overrides on the standard Logger.
+// See Logger.VModule for the rule syntax.
+func VModule(spec string) error {
+	return std.VModule(spec)
+}
+
+// SetVerbosityFromEnv sets the standard Logger's verbosity by parsing the
+// named environment variable as an int, in the style of a "-v=N" flag. It
+// is a no-op if the variable is unset or isn't a valid int.
+func SetVerbosityFromEnv(name string) {
+	v, err := strconv.Atoi(os.Getenv(name))
+	if err != nil {
+		return
+	}
+	std.SetVerbosity(v)
+}
+
 // DebugFunc will execute fn if the DEBUG Level is enabled on the standard Logger
 // and will print the returned string to the log
 func DebugFunc(fn func() string) {
 	if std.enabled&DEBUG != DEBUG {
 		return
 	}
-	std.log(DEBUG, fn())
+	std.log(DEBUG, fn(), nil, 0)
 }
 
 // Debug will print in the manner of fmt.Print to the standard Logger if the DEBUG Level is enabled
@@ -74,7 +122,7 @@ func Debug(v ...interface{}) {
 	if std.enabled&DEBUG != DEBUG {
 		return
 	}
-	std.log(DEBUG, fmt.Sprint(v...))
+	std.log(DEBUG, fmt.Sprint(v...), nil, 0)
 }
 
 // Debugln will print in the manner of fmt.Println to the standard Logger if the DEBUG Level is enabled
@@ -82,7 +130,7 @@ func Debugln(v ...interface{}) {
 	if std.enabled&DEBUG != DEBUG {
 		return
 	}
-	std.log(DEBUG, fmt.Sprintln(v...))
+	std.log(DEBUG, fmt.Sprintln(v...), nil, 0)
 }
 
 // Debugf will print in the manner of fmt.Printf to the standard Logger if the DEBUG Level is enabled
@@ -90,7 +138,7 @@ func Debugf(format string, v ...interface{}) {
 	if std.enabled&DEBUG != DEBUG {
 		return
 	}
-	std.log(DEBUG, fmt.Sprintf(format, v...))
+	std.log(DEBUG, fmt.Sprintf(format, v...), nil, 0)
 }
 
 // Print will print in the manner of fmt.Print to the standard Logger if the NORMAL Level is enabled
@@ -98,7 +146,7 @@ func Print(v ...interface{}) {
 	if std.enabled&NORMAL != NORMAL {
 		return
 	}
-	std.log(NORMAL, fmt.Sprint(v...))
+	std.log(NORMAL, fmt.Sprint(v...), nil, 0)
 }
 
 // Println will print in the manner of fmt.Println to the standard Logger if the NORMAL Level is enabled
@@ -106,7 +154,7 @@ func Println(v ...interface{}) {
 	if std.enabled&NORMAL != NORMAL {
 		return
 	}
-	std.log(NORMAL, fmt.Sprintln(v...))
+	std.log(NORMAL, fmt.Sprintln(v...), nil, 0)
 }
 
 // Printf will print in the manner of fmt.Printf to the standard Logger if the NORMAL Level is enabled
@@ -114,7 +162,7 @@ func Printf(format string, v ...interface{}) {
 	if std.enabled&NORMAL != NORMAL {
 		return
 	}
-	std.log(NORMAL, fmt.Sprintf(format, v...))
+	std.log(NORMAL, fmt.Sprintf(format, v...), nil, 0)
 }
 
 // Warning will print in the manner of fmt.Print to the standard Logger if the WARNING Level is enabled
@@ -122,7 +170,7 @@ func Warning(v ...interface{}) {
 	if std.enabled&WARNING != WARNING {
 		return
 	}
-	std.log(WARNING, fmt.Sprint(v...))
+	std.log(WARNING, fmt.Sprint(v...), nil, 0)
 }
 
 // Warningln will print in the manner of fmt.Println to the standard Logger if the WARNING Level is enabled
@@ -130,7 +178,7 @@ func Warningln(v ...interface{}) {
 	if std.enabled&WARNING != WARNING {
 		return
 	}
-	std.log(WARNING, fmt.Sprintln(v...))
+	std.log(WARNING, fmt.Sprintln(v...), nil, 0)
 }
 
 // Warningf will print in the manner of fmt.Printf to the standard Logger if the WARNING Level is enabled
@@ -138,7 +186,7 @@ func Warningf(format string, v ...interface{}) {
 	if std.enabled&WARNING != WARNING {
 		return
 	}
-	std.log(WARNING, fmt.Sprintf(format, v...))
+	std.log(WARNING, fmt.Sprintf(format, v...), nil, 0)
 }
 
 // Error will print in the manner of fmt.Print to the standard Logger if the ERROR Level is enabled
@@ -146,7 +194,7 @@ func Error(v ...interface{}) {
 	if std.enabled&ERROR != ERROR {
 		return
 	}
-	std.log(ERROR, fmt.Sprint(v...))
+	std.log(ERROR, fmt.Sprint(v...), nil, 0)
 }
 
 // Errorln will print in the manner of fmt.Println to the standard Logger if the ERROR Level is enabled
@@ -154,7 +202,7 @@ func Errorln(v ...interface{}) {
 	if std.enabled&ERROR != ERROR {
 		return
 	}
-	std.log(ERROR, fmt.Sprintln(v...))
+	std.log(ERROR, fmt.Sprintln(v...), nil, 0)
 }
 
 // Errorf will print in the manner of fmt.Printf to the standard Logger if the ERROR Level is enabled
@@ -162,7 +210,7 @@ func Errorf(format string, v ...interface{}) {
 	if std.enabled&ERROR != ERROR {
 		return
 	}
-	std.log(ERROR, fmt.Sprintf(format, v...))
+	std.log(ERROR, fmt.Sprintf(format, v...), nil, 0)
 }
 
 // Panic will print in the manner of fmt.Print to the standard Logger if the PANIC Level is enabled
@@ -172,7 +220,7 @@ func Panic(v ...interface{}) {
 		return
 	}
 	msg := fmt.Sprint(v...)
-	std.log(PANIC, msg)
+	std.log(PANIC, msg, nil, 0)
 	panic(msg)
 }
 
@@ -183,7 +231,7 @@ func Panicln(v ...interface{}) {
 		return
 	}
 	msg := fmt.Sprintln(v...)
-	std.log(PANIC, msg)
+	std.log(PANIC, msg, nil, 0)
 	panic(msg)
 }
 
@@ -194,7 +242,7 @@ func Panicf(format string, v ...interface{}) {
 		return
 	}
 	msg := fmt.Sprintf(format, v...)
-	std.log(PANIC, msg)
+	std.log(PANIC, msg, nil, 0)
 	panic(msg)
 }
 
@@ -204,7 +252,7 @@ func Fatal(v ...interface{}) {
 	if std.enabled&FATAL != FATAL {
 		return
 	}
-	std.log(FATAL, fmt.Sprint(v...))
+	std.log(FATAL, fmt.Sprint(v...), nil, 0)
 	os.Exit(1)
 }
 
@@ -214,7 +262,7 @@ func Fatalln(v ...interface{}) {
 	if std.enabled&FATAL != FATAL {
 		return
 	}
-	std.log(FATAL, fmt.Sprintln(v...))
+	std.log(FATAL, fmt.Sprintln(v...), nil, 0)
 	os.Exit(1)
 }
 
@@ -224,6 +272,6 @@ func Fatalf(format string, v ...interface{}) {
 	if std.enabled&FATAL != FATAL {
 		return
 	}
-	std.log(FATAL, fmt.Sprintf(format, v...))
+	std.log(FATAL, fmt.Sprintf(format, v...), nil, 0)
 	os.Exit(1)
 }
@@ -0,0 +1,67 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+// +build !windows
+
+package log
+
+import "log/syslog"
+
+// A SyslogHook fires selected log messages off to the local or a remote
+// syslog daemon, mapping this package's Levels onto syslog priorities.
+type SyslogHook struct {
+	levels Level
+	writer *syslog.Writer
+}
+
+// NewSyslogHook dials network/raddr (see net.Dial; network == "" dials the
+// local syslog socket) and returns a SyslogHook that fires on levels.
+func NewSyslogHook(network, raddr, tag string, levels Level) (*SyslogHook, error) {
+	w, err := syslog.Dial(network, raddr, syslog.LOG_INFO, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &SyslogHook{levels: levels, writer: w}, nil
+}
+
+// Levels returns the bit mask of Levels this Hook fires on.
+func (h *SyslogHook) Levels() Level {
+	return h.levels
+}
+
+// Fire ships m.Body to syslog at the priority matching m.Level.
+func (h *SyslogHook) Fire(m *Msg) error {
+	switch m.Level {
+	case DEBUG:
+		return h.writer.Debug(m.Body)
+	case WARNING:
+		return h.writer.Warning(m.Body)
+	case ERROR:
+		return h.writer.Err(m.Body)
+	case PANIC:
+		return h.writer.Crit(m.Body)
+	case FATAL:
+		return h.writer.Emerg(m.Body)
+	default:
+		return h.writer.Info(m.Body)
+	}
+}
@@ -0,0 +1,50 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+// +build windows
+
+package log
+
+import "errors"
+
+// A SyslogHook fires selected log messages off to syslog. syslog doesn't
+// exist on Windows, so NewSyslogHook always fails here; the type is kept so
+// cross-platform callers can still reference it behind a build-independent
+// Hook interface.
+type SyslogHook struct {
+	levels Level
+}
+
+// NewSyslogHook always returns an error on Windows.
+func NewSyslogHook(network, raddr, tag string, levels Level) (*SyslogHook, error) {
+	return nil, errors.New("log: SyslogHook is not supported on windows")
+}
+
+// Levels returns the bit mask of Levels this Hook fires on.
+func (h *SyslogHook) Levels() Level {
+	return h.levels
+}
+
+// Fire is a no-op on Windows.
+func (h *SyslogHook) Fire(m *Msg) error {
+	return nil
+}
@@ -0,0 +1,254 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package log
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// A Facility identifies the RFC 5424 facility a SyslogSink logs under.
+type Facility int
+
+// Facilities commonly used by application loggers. The full RFC 5424 table
+// has more (kern, mail, ...); these are the ones operators actually pick
+// between for a Go service.
+const (
+	FacilityUser   Facility = 1
+	FacilityDaemon Facility = 3
+	FacilityLocal0 Facility = 16
+	FacilityLocal1 Facility = 17
+	FacilityLocal2 Facility = 18
+	FacilityLocal3 Facility = 19
+	FacilityLocal4 Facility = 20
+	FacilityLocal5 Facility = 21
+	FacilityLocal6 Facility = 22
+	FacilityLocal7 Facility = 23
+)
+
+// reconnectPollInterval is how often run checks whether a previously
+// healthy connection has dropped.
+const reconnectPollInterval = 500 * time.Millisecond
+
+// severityFor maps this package's Level onto an RFC 5424 severity.
+func severityFor(lvl Level) int {
+	switch lvl {
+	case DEBUG:
+		return 7
+	case WARNING:
+		return 4
+	case ERROR:
+		return 3
+	case PANIC:
+		return 2
+	case FATAL:
+		return 0
+	default: // NORMAL
+		return 6
+	}
+}
+
+// A SyslogSink ships log messages to a local or remote syslog daemon as
+// RFC 5424 formatted lines, over a local socket ("unixgram", addr
+// "/dev/log") or a remote UDP/TCP/TLS endpoint.
+type SyslogSink struct {
+	Network  string // "unixgram", "udp", "tcp", or "tls"
+	Addr     string
+	Facility Facility
+	Tag      string
+	Hostname string
+	// MaxBuffered bounds how many formatted lines are held in memory while
+	// the remote end is unreachable; the oldest is dropped once full.
+	MaxBuffered int
+	TLSConfig   *tls.Config
+
+	mu      sync.Mutex
+	conn    net.Conn
+	buf     [][]byte
+	backoff time.Duration
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewSyslogSink constructs a SyslogSink and makes a first connection
+// attempt. A failed first attempt is not fatal: the sink buffers messages
+// and a background goroutine keeps retrying with backoff.
+func NewSyslogSink(network, addr string, facility Facility, tag, hostname string, maxBuffered int) *SyslogSink {
+	if hostname == "" {
+		hostname, _ = os.Hostname()
+	}
+	s := &SyslogSink{
+		Network:     network,
+		Addr:        addr,
+		Facility:    facility,
+		Tag:         tag,
+		Hostname:    hostname,
+		MaxBuffered: maxBuffered,
+		backoff:     100 * time.Millisecond,
+		done:        make(chan struct{}),
+	}
+	s.conn = s.dial()
+	s.wg.Add(1)
+	go s.run()
+	return s
+}
+
+// dial attempts to connect to s.Network/s.Addr and returns the new
+// connection, or nil if the attempt failed. It performs the actual network
+// dial without holding s.mu, since a dial can block for some time; callers
+// take s.mu themselves around assigning the result to s.conn.
+func (s *SyslogSink) dial() net.Conn {
+	var conn net.Conn
+	var err error
+	if s.Network == "tls" {
+		conn, err = tls.Dial("tcp", s.Addr, s.TLSConfig)
+	} else {
+		conn, err = net.Dial(s.Network, s.Addr)
+	}
+	if err != nil {
+		return nil
+	}
+	return conn
+}
+
+// format renders m as an RFC 5424 line.
+func (s *SyslogSink) format(m *Msg) []byte {
+	pri := int(s.Facility)*8 + severityFor(m.Level)
+	ts := m.Time.UTC().Format("2006-01-02T15:04:05.000Z")
+	return []byte(fmt.Sprintf("<%d>1 %s %s %s %d - [origin file=\"%s\" line=\"%d\"] %s\n",
+		pri, ts, s.Hostname, s.Tag, os.Getpid(), m.File, m.Line, m.Body))
+}
+
+// Log formats m and writes it to the current connection. If the remote end
+// is currently unreachable, Log buffers the line in memory and returns
+// immediately rather than dialing or sleeping on the backoff itself; run
+// reconnects in the background.
+func (s *SyslogSink) Log(m *Msg) {
+	line := s.format(m)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		s.enqueue(line)
+		return
+	}
+
+	if _, err := s.conn.Write(line); err == nil {
+		return
+	}
+	s.conn.Close()
+	s.conn = nil
+	s.enqueue(line)
+}
+
+// run reconnects in the background whenever s.conn is nil, backing off
+// exponentially between dial attempts up to a 30 second ceiling, and
+// polls a healthy connection so it notices if Log marks it dead. It exits
+// once Close closes s.done.
+func (s *SyslogSink) run() {
+	defer s.wg.Done()
+	for {
+		s.mu.Lock()
+		down := s.conn == nil
+		s.mu.Unlock()
+
+		if !down {
+			select {
+			case <-s.done:
+				return
+			case <-time.After(reconnectPollInterval):
+			}
+			continue
+		}
+
+		conn := s.dial()
+
+		s.mu.Lock()
+		if conn != nil {
+			s.conn = conn
+			s.backoff = 100 * time.Millisecond
+			s.flushBuffered()
+		} else if s.backoff < 30*time.Second {
+			s.backoff *= 2
+			if s.backoff > 30*time.Second {
+				s.backoff = 30 * time.Second
+			}
+		}
+		wait := s.backoff
+		s.mu.Unlock()
+
+		if conn != nil {
+			continue
+		}
+		select {
+		case <-s.done:
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// enqueue appends line to the bounded buffer, dropping the oldest entry
+// once MaxBuffered is reached.
+func (s *SyslogSink) enqueue(line []byte) {
+	if s.MaxBuffered <= 0 {
+		return
+	}
+	if len(s.buf) >= s.MaxBuffered {
+		s.buf = s.buf[1:]
+	}
+	s.buf = append(s.buf, line)
+}
+
+// flushBuffered writes any buffered lines now that the connection is back.
+func (s *SyslogSink) flushBuffered() {
+	for len(s.buf) > 0 {
+		line := s.buf[0]
+		if _, err := s.conn.Write(line); err != nil {
+			return
+		}
+		s.buf = s.buf[1:]
+	}
+}
+
+// Close stops the background reconnect goroutine and closes the underlying
+// connection, if any.
+func (s *SyslogSink) Close() error {
+	close(s.done)
+	s.wg.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
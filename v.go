@@ -0,0 +1,224 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package log
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// vmoduleRule matches a glob pattern against a Msg's File to a verbosity level.
+type vmoduleRule struct {
+	pattern string
+	level   int32
+}
+
+// vmoduleRules is installed atomically so call sites can read it lock-free.
+type vmoduleRules []vmoduleRule
+
+// noRuleLevel is the matchLevel result (and vmoduleCache entry) for a file
+// that no vmodule rule matches.
+const noRuleLevel int32 = -1
+
+// matchLevel returns the verbosity level the first matching rule assigns to
+// file, or noRuleLevel if no rule matches.
+func (rules vmoduleRules) matchLevel(file string) int32 {
+	base := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	for _, r := range rules {
+		if ok, _ := filepath.Match(r.pattern, base); ok {
+			return r.level
+		}
+		if ok, _ := filepath.Match(r.pattern, file); ok {
+			return r.level
+		}
+	}
+	return noRuleLevel
+}
+
+// A MinVerbosityFilter is implemented by Sinks that only want to receive
+// V-leveled messages up to a maximum verbosity. A sink's Log is skipped for
+// any Msg whose VLevel exceeds MinVerbosity(); Msgs logged outside V (VLevel
+// 0) are never filtered this way.
+type MinVerbosityFilter interface {
+	MinVerbosity() int
+}
+
+// A Verbose is returned by Logger.V and gates a block of verbose logging
+// calls behind a single enabled check made at the V call site.
+type Verbose struct {
+	logger  *Logger
+	level   int
+	enabled bool
+}
+
+// V reports whether verbosity level is enabled on the receiving Logger,
+// either because the Logger's global verbosity is at least level, or
+// because a VModule rule matches the calling file at that level.
+//
+// When the global verbosity is at least level, this is a single atomic load
+// and compare; otherwise one more atomic load checks for VModule rules. When
+// rules are installed, the rule matching the calling file is resolved once
+// per call site (keyed by its PC) and cached in l.vmoduleCache, so repeated
+// calls from the same V(n) call site after the first skip the glob match,
+// though each call still makes a runtime.Caller call to find its PC.
+func (l *Logger) V(level int) Verbose {
+	return l.vAtDepth(level, 2)
+}
+
+// vAtDepth is V's implementation, parameterized on the runtime.Caller skip
+// depth so that a thin wrapper around V (such as the package-level V in
+// std.go) can pass a depth that still resolves VModule rules against its
+// own caller rather than against the wrapper itself. skip is passed
+// straight to runtime.Caller from inside vAtDepth, so depth 2 is "the
+// caller of the function that called vAtDepth" - the right value both for
+// V calling vAtDepth directly and for a single-frame wrapper calling
+// vAtDepth in V's place.
+func (l *Logger) vAtDepth(level int, skip int) Verbose {
+	if atomic.LoadInt32(&l.verbosity) >= int32(level) {
+		return Verbose{logger: l, level: level, enabled: true}
+	}
+
+	rules, _ := l.vmodule.Load().(vmoduleRules)
+	if len(rules) == 0 {
+		return Verbose{logger: l, level: level, enabled: false}
+	}
+
+	pc, file, _, ok := runtime.Caller(skip)
+	if !ok {
+		return Verbose{logger: l, level: level, enabled: false}
+	}
+
+	var ruleLevel int32
+	if cached, ok := l.vmoduleCache.Load(pc); ok {
+		ruleLevel = cached.(int32)
+	} else {
+		// trim the path to $GOPATH/src/, as Logger.log does for Msg.File
+		file = filepath.ToSlash(file)
+		if n := strings.LastIndex(file, "/src/"); n > 0 {
+			file = file[n+5:]
+		}
+		ruleLevel = rules.matchLevel(file)
+		l.vmoduleCache.Store(pc, ruleLevel)
+	}
+
+	return Verbose{logger: l, level: level, enabled: ruleLevel != noRuleLevel && int32(level) <= ruleLevel}
+}
+
+// Print logs in the manner of fmt.Print if the Verbose is enabled.
+func (v Verbose) Print(args ...interface{}) {
+	if !v.enabled {
+		return
+	}
+	v.logger.log(NORMAL, fmt.Sprint(args...), nil, v.level)
+}
+
+// Println logs in the manner of fmt.Println if the Verbose is enabled.
+func (v Verbose) Println(args ...interface{}) {
+	if !v.enabled {
+		return
+	}
+	v.logger.log(NORMAL, fmt.Sprintln(args...), nil, v.level)
+}
+
+// Printf logs in the manner of fmt.Printf if the Verbose is enabled.
+func (v Verbose) Printf(format string, args ...interface{}) {
+	if !v.enabled {
+		return
+	}
+	v.logger.log(NORMAL, fmt.Sprintf(format, args...), nil, v.level)
+}
+
+// Info logs msg if the Verbose is enabled. It is equivalent to Println
+// but reads more naturally for a single already-built message string.
+func (v Verbose) Info(msg string) {
+	if !v.enabled {
+		return
+	}
+	v.logger.log(NORMAL, msg, nil, v.level)
+}
+
+// Log logs in the manner of fmt.Print if the Verbose is enabled. It is an
+// alias for Print, named to match glog/klog's V(n).Log convention.
+func (v Verbose) Log(args ...interface{}) {
+	if !v.enabled {
+		return
+	}
+	v.logger.log(NORMAL, fmt.Sprint(args...), nil, v.level)
+}
+
+// Logln logs in the manner of fmt.Println if the Verbose is enabled. It is
+// an alias for Println, named to match glog/klog's V(n).Logln convention.
+func (v Verbose) Logln(args ...interface{}) {
+	if !v.enabled {
+		return
+	}
+	v.logger.log(NORMAL, fmt.Sprintln(args...), nil, v.level)
+}
+
+// Logf logs in the manner of fmt.Printf if the Verbose is enabled. It is an
+// alias for Printf, named to match glog/klog's V(n).Logf convention.
+func (v Verbose) Logf(format string, args ...interface{}) {
+	if !v.enabled {
+		return
+	}
+	v.logger.log(NORMAL, fmt.Sprintf(format, args...), nil, v.level)
+}
+
+// SetVerbosity sets the numeric verbosity level on the receiving Logger.
+// V(level) is enabled whenever level is less than or equal to n.
+func (l *Logger) SetVerbosity(n int) {
+	atomic.StoreInt32(&l.verbosity, int32(n))
+}
+
+// VModule sets per-file verbosity overrides on the receiving Logger from a
+// comma-separated list of glob=level rules, e.g. "worker=2,reconcile*=4".
+// Patterns are matched against both the full (trimmed) file path and its
+// basename without extension.
+func (l *Logger) VModule(spec string) error {
+	var rules vmoduleRules
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("log: invalid vmodule rule %q", part)
+		}
+		level, err := strconv.Atoi(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return fmt.Errorf("log: invalid vmodule level in %q: %v", part, err)
+		}
+		rules = append(rules, vmoduleRule{pattern: strings.TrimSpace(kv[0]), level: int32(level)})
+	}
+	l.vmodule.Store(rules)
+	l.vmoduleCache.Range(func(k, _ interface{}) bool {
+		l.vmoduleCache.Delete(k)
+		return true
+	})
+	return nil
+}